@@ -0,0 +1,238 @@
+/*
+Copyright (c) 2024-2024 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tpm
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/vmware/govmomi/govc/cli"
+	"github.com/vmware/govmomi/govc/flags"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/methods"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+type report struct {
+	*flags.HostSystemFlag
+	*flags.OutputFlag
+
+	pcr    string
+	verify bool
+}
+
+func init() {
+	cli.Register("host.tpm.report", &report{})
+}
+
+func (cmd *report) Register(ctx context.Context, f *flag.FlagSet) {
+	cmd.HostSystemFlag, ctx = flags.NewHostSystemFlag(ctx)
+	cmd.HostSystemFlag.Register(ctx, f)
+	cmd.OutputFlag, ctx = flags.NewOutputFlag(ctx)
+	cmd.OutputFlag.Register(ctx, f)
+
+	f.StringVar(&cmd.pcr, "pcr", "", "Limit output to a comma-separated set of PCR indices")
+	f.BoolVar(&cmd.verify, "verify", false, "Re-hash the event log and flag PCRs that do not reconstruct to the reported value")
+}
+
+func (cmd *report) Process(ctx context.Context) error {
+	if err := cmd.HostSystemFlag.Process(ctx); err != nil {
+		return err
+	}
+	return cmd.OutputFlag.Process(ctx)
+}
+
+func (cmd *report) Description() string {
+	return `TPM attestation report: event log and PCR values for a host.
+
+Unlike host.tpm.info, which only summarizes attestation status, this command
+decodes the full PCR bank and event log so individual events can be
+inspected or re-verified.
+
+Examples:
+  govc host.tpm.report -host my_host
+  govc host.tpm.report -host my_host -pcr 0,1,7 -json
+  govc host.tpm.report -host my_host -verify`
+}
+
+func (cmd *report) pcrFilter() (map[int32]bool, error) {
+	if cmd.pcr == "" {
+		return nil, nil
+	}
+
+	m := make(map[int32]bool)
+	for _, s := range strings.Split(cmd.pcr, ",") {
+		n, err := strconv.ParseInt(strings.TrimSpace(s), 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid -pcr value %q: %w", s, err)
+		}
+		m[int32(n)] = true
+	}
+
+	return m, nil
+}
+
+// PcrEvent is a single TPM event log entry extending a PCR.
+type PcrEvent struct {
+	Type   string `json:"type"`
+	Digest string `json:"digest"`
+}
+
+// PcrBank is the reported value of one PCR index, plus the event log
+// entries that extended it.
+type PcrBank struct {
+	Index    int32      `json:"index"`
+	Digest   string     `json:"digest"`
+	Events   []PcrEvent `json:"events"`
+	Verified *bool      `json:"verified,omitempty"`
+}
+
+// AttestationReport is the decoded form of a host's TPM attestation report.
+type AttestationReport struct {
+	Host      string    `json:"host"`
+	Signature string    `json:"signature,omitempty"`
+	Banks     []PcrBank `json:"banks"`
+}
+
+// HostTpmAttestationReport fetches and decodes the TPM attestation report
+// for host, combining the PCR bank values with the event log entries that
+// produced them.
+func HostTpmAttestationReport(ctx context.Context, host *object.HostSystem) (*AttestationReport, error) {
+	req := types.QueryTpmAttestationReport{
+		This: host.Reference(),
+	}
+
+	res, err := methods.QueryTpmAttestationReport(ctx, host.Client(), &req)
+	if err != nil {
+		return nil, err
+	}
+
+	info := res.Returnval
+	if info == nil {
+		return nil, fmt.Errorf("host.tpm.report: host %q has no attestation report", host.Name())
+	}
+
+	byIndex := make(map[int32][]PcrEvent)
+	for _, e := range info.TpmEvents {
+		byIndex[e.PcrIndex] = append(byIndex[e.PcrIndex], PcrEvent{
+			Type:   e.EventType,
+			Digest: hex.EncodeToString(e.EventDataDigest),
+		})
+	}
+
+	r := &AttestationReport{Host: host.Name()}
+	if info.TpmSignature != nil {
+		r.Signature = hex.EncodeToString(info.TpmSignature)
+	}
+
+	for _, pcr := range info.TpmPcrValues {
+		r.Banks = append(r.Banks, PcrBank{
+			Index:  pcr.PcrNumber,
+			Digest: hex.EncodeToString(pcr.Digest),
+			Events: byIndex[pcr.PcrNumber],
+		})
+	}
+
+	sort.Slice(r.Banks, func(i, j int) bool { return r.Banks[i].Index < r.Banks[j].Index })
+
+	return r, nil
+}
+
+// verifyBanks re-hashes each bank's event log (PCR_new = SHA256(PCR_old ||
+// event_digest), starting from an all-zero PCR) and flags banks whose
+// reconstructed value does not match the reported digest.
+func verifyBanks(banks []PcrBank) {
+	for i := range banks {
+		b := &banks[i]
+
+		pcr := make([]byte, sha256.Size)
+		for _, e := range b.Events {
+			d, err := hex.DecodeString(e.Digest)
+			if err != nil {
+				continue
+			}
+
+			h := sha256.New()
+			h.Write(pcr)
+			h.Write(d)
+			pcr = h.Sum(nil)
+		}
+		reconstructed := hex.EncodeToString(pcr)
+
+		ok := reconstructed == b.Digest
+		b.Verified = &ok
+	}
+}
+
+func (cmd *report) Run(ctx context.Context, f *flag.FlagSet) error {
+	host, err := cmd.HostSystemFlag.HostSystem()
+	if err != nil {
+		return err
+	}
+
+	r, err := HostTpmAttestationReport(ctx, host)
+	if err != nil {
+		return err
+	}
+
+	filter, err := cmd.pcrFilter()
+	if err != nil {
+		return err
+	}
+	if filter != nil {
+		var filtered []PcrBank
+		for _, b := range r.Banks {
+			if filter[b.Index] {
+				filtered = append(filtered, b)
+			}
+		}
+		r.Banks = filtered
+	}
+
+	if cmd.verify {
+		verifyBanks(r.Banks)
+	}
+
+	return cmd.WriteResult(reportResult{r})
+}
+
+type reportResult struct {
+	*AttestationReport
+}
+
+func (r reportResult) Write(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 2, 0, 2, ' ', 0)
+
+	fmt.Fprintln(tw, "PCR\tDigest\tEvents\tVerified")
+	for _, b := range r.Banks {
+		verified := "-"
+		if b.Verified != nil {
+			verified = strconv.FormatBool(*b.Verified)
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%d\t%s\n", b.Index, b.Digest, len(b.Events), verified)
+	}
+
+	return tw.Flush()
+}
@@ -0,0 +1,84 @@
+/*
+Copyright (c) 2024-2024 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tpm
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+)
+
+// extend is the reference PCR-extend algorithm: PCR_new = SHA256(PCR_old ||
+// digest), starting from an all-zero PCR. It is used here only to derive
+// expected values independently of verifyBanks.
+func extend(digests ...string) string {
+	pcr := make([]byte, sha256.Size)
+	for _, digest := range digests {
+		d, _ := hex.DecodeString(digest)
+		h := sha256.New()
+		h.Write(pcr)
+		h.Write(d)
+		pcr = h.Sum(nil)
+	}
+	return hex.EncodeToString(pcr)
+}
+
+func TestVerifyBanksMultiEventChain(t *testing.T) {
+	d1 := hex.EncodeToString([]byte("event-one-digest-32-bytes-long!"))
+	d2 := hex.EncodeToString([]byte("event-two-digest-32-bytes-long!"))
+	d3 := hex.EncodeToString([]byte("event-three-digest-32-byte-long"))
+
+	banks := []PcrBank{
+		{
+			Index:  0,
+			Digest: extend(d1, d2, d3),
+			Events: []PcrEvent{{Digest: d1}, {Digest: d2}, {Digest: d3}},
+		},
+		{
+			Index:  1,
+			Digest: "0000000000000000000000000000000000000000000000000000000000000000",
+			Events: []PcrEvent{{Digest: d1}, {Digest: d2}, {Digest: d3}},
+		},
+	}
+
+	verifyBanks(banks)
+
+	if banks[0].Verified == nil || !*banks[0].Verified {
+		t.Errorf("expected bank 0 (untampered, multi-event chain) to verify")
+	}
+	if banks[1].Verified == nil || *banks[1].Verified {
+		t.Errorf("expected bank 1 (mismatched reported digest) to fail verification")
+	}
+}
+
+func TestVerifyBanksSingleEvent(t *testing.T) {
+	d1 := hex.EncodeToString([]byte("single-event-digest-32-bytes-lo"))
+
+	banks := []PcrBank{
+		{
+			Index:  7,
+			Digest: extend(d1),
+			Events: []PcrEvent{{Digest: d1}},
+		},
+	}
+
+	verifyBanks(banks)
+
+	if banks[0].Verified == nil || !*banks[0].Verified {
+		t.Errorf("expected single-event bank to verify")
+	}
+}
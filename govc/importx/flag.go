@@ -0,0 +1,142 @@
+/*
+Copyright (c) 2024-2024 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package importx
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/vmware/govmomi/govc/flags"
+	"github.com/vmware/govmomi/ovf/importer"
+	"github.com/vmware/govmomi/vim25/progress"
+)
+
+// archiveFlag binds the Options fields shared by import.ovf and import.ova
+// to a flag.FlagSet, and resolves the placement flags (pool/datastore/
+// folder/host) into an *importer.Importer.
+type archiveFlag struct {
+	*flags.DatastoreFlag
+	*flags.ResourcePoolFlag
+	*flags.FolderFlag
+	*flags.HostSystemFlag
+	*flags.OutputFlag
+
+	importer.Options
+
+	name            string
+	networkMapping  flags.StringList
+	propertyMapping flags.StringList
+}
+
+func (cmd *archiveFlag) Register(ctx context.Context, f *flag.FlagSet) {
+	cmd.DatastoreFlag, ctx = flags.NewDatastoreFlag(ctx)
+	cmd.DatastoreFlag.Register(ctx, f)
+	cmd.ResourcePoolFlag, ctx = flags.NewResourcePoolFlag(ctx)
+	cmd.ResourcePoolFlag.Register(ctx, f)
+	cmd.FolderFlag, ctx = flags.NewFolderFlag(ctx)
+	cmd.FolderFlag.Register(ctx, f)
+	cmd.HostSystemFlag, ctx = flags.NewHostSystemFlag(ctx)
+	cmd.HostSystemFlag.Register(ctx, f)
+	cmd.OutputFlag, ctx = flags.NewOutputFlag(ctx)
+	cmd.OutputFlag.Register(ctx, f)
+
+	f.StringVar(&cmd.Options.Deployment, "deployment", "", "Deployment configuration")
+	f.StringVar(&cmd.Options.IPAllocationPolicy, "ip-allocation-policy", "dhcpPolicy", "IP allocation scheme")
+	f.StringVar(&cmd.Options.IPProtocol, "ip-protocol", "IPv4", "IP protocol")
+	f.StringVar(&cmd.Options.Annotation, "annotation", "", "User-provided annotation (accepted but not applied by this command)")
+	f.StringVar(&cmd.name, "name", "", "Name to assign the imported VM or vApp (default: the descriptor file name without its extension)")
+	f.BoolVar(&cmd.Options.PowerOn, "powerOn", false, "Power on VM (accepted but not applied by this command)")
+	f.BoolVar(&cmd.Options.InjectOvfEnv, "inject-ovf-env", false, "Inject the OVF environment as a vApp property (accepted but not applied by this command)")
+	f.BoolVar(&cmd.Options.WaitForIP, "wait-for-ip", false, "Wait for VM to acquire IP address (accepted but not applied by this command)")
+	f.BoolVar(&cmd.Options.MarkAsTemplate, "as-template", false, "Mark VM as template (accepted but not applied by this command)")
+	f.Var(&cmd.networkMapping, "net", "Network mapping, SOURCE:DEST")
+	f.Var(&cmd.propertyMapping, "prop", "Property mapping, KEY=VALUE")
+}
+
+func (cmd *archiveFlag) Process(ctx context.Context) error {
+	for _, p := range []interface {
+		Process(context.Context) error
+	}{
+		cmd.DatastoreFlag, cmd.ResourcePoolFlag, cmd.FolderFlag, cmd.HostSystemFlag, cmd.OutputFlag,
+	} {
+		if err := p.Process(ctx); err != nil {
+			return err
+		}
+	}
+
+	if cmd.name != "" {
+		cmd.Options.Name = &cmd.name
+	}
+
+	for _, m := range cmd.networkMapping {
+		src, dst, ok := strings.Cut(m, ":")
+		if !ok {
+			return fmt.Errorf("invalid -net mapping %q, expected SOURCE:DEST", m)
+		}
+		cmd.Options.NetworkMapping = append(cmd.Options.NetworkMapping, importer.NetworkMapping{Name: src, Network: dst})
+	}
+
+	for _, p := range cmd.propertyMapping {
+		key, value, ok := strings.Cut(p, "=")
+		if !ok {
+			return fmt.Errorf("invalid -prop mapping %q, expected KEY=VALUE", p)
+		}
+		cmd.Options.PropertyMapping = append(cmd.Options.PropertyMapping, importer.PropertyMapping{Key: key, Value: value})
+	}
+
+	return nil
+}
+
+// Importer resolves the flags into an *importer.Importer bound to the
+// current client and placement.
+func (cmd *archiveFlag) Importer(ctx context.Context) (*importer.Importer, error) {
+	c, err := cmd.DatastoreFlag.Client()
+	if err != nil {
+		return nil, err
+	}
+
+	ds, err := cmd.DatastoreFlag.Datastore()
+	if err != nil {
+		return nil, err
+	}
+
+	pool, err := cmd.ResourcePoolFlag.ResourcePool()
+	if err != nil {
+		return nil, err
+	}
+
+	folder, err := cmd.FolderFlag.Folder()
+	if err != nil {
+		return nil, err
+	}
+
+	host, err := cmd.HostSystemFlag.HostSystemIfSpecified()
+	if err != nil {
+		return nil, err
+	}
+
+	imp := importer.NewImporter(c)
+	imp.Datastore = ds
+	imp.ResourcePool = pool
+	imp.Folder = folder
+	imp.Host = host
+	imp.Log = progress.Logger(cmd.Log, "Import")
+
+	return imp, nil
+}
@@ -0,0 +1,90 @@
+/*
+Copyright (c) 2024-2024 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package importx
+
+import (
+	"context"
+	"flag"
+	"path"
+	"strings"
+
+	"github.com/vmware/govmomi/govc/cli"
+	"github.com/vmware/govmomi/ovf/importer"
+)
+
+// ova reads the .ovf descriptor and disks directly out of an .ova tar
+// archive, reusing the same ovf/importer.Importer as import.ovf.
+type ova struct {
+	*archiveFlag
+
+	Path string
+}
+
+func init() {
+	cli.Register("import.ova", &ova{})
+}
+
+func (cmd *ova) Register(ctx context.Context, f *flag.FlagSet) {
+	cmd.archiveFlag, ctx = &archiveFlag{}, ctx
+	cmd.archiveFlag.Register(ctx, f)
+}
+
+func (cmd *ova) Process(ctx context.Context) error {
+	return cmd.archiveFlag.Process(ctx)
+}
+
+func (cmd *ova) Usage() string {
+	return "PATH_TO_OVA"
+}
+
+func (cmd *ova) Description() string {
+	return `Deploy VM or vApp from an OVA, without unpacking it to disk first.
+
+Examples:
+  govc import.ova vm.ova`
+}
+
+func (cmd *ova) Run(ctx context.Context, f *flag.FlagSet) error {
+	if f.NArg() != 1 {
+		return flag.ErrHelp
+	}
+	cmd.Path = f.Arg(0)
+
+	imp, err := cmd.Importer(ctx)
+	if err != nil {
+		return err
+	}
+
+	archive := &importer.TapeArchive{Path: cmd.Path}
+
+	descriptor, err := archive.Resolve("*.ovf")
+	if err != nil {
+		return err
+	}
+
+	if cmd.Options.Name == nil {
+		name := strings.TrimSuffix(path.Base(descriptor), path.Ext(descriptor))
+		cmd.Options.Name = &name
+	}
+
+	ref, err := imp.Import(ctx, archive, descriptor, cmd.Options)
+	if err != nil {
+		return err
+	}
+
+	return cmd.WriteResult(importResult{ref.Value})
+}
@@ -0,0 +1,101 @@
+/*
+Copyright (c) 2024-2024 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package importx
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/vmware/govmomi/govc/cli"
+	"github.com/vmware/govmomi/ovf/importer"
+)
+
+// ovfx is a thin CLI wrapper over ovf/importer.Importer: all archive
+// traversal, spec generation and upload logic lives in that package so
+// non-govc consumers can call it directly.
+type ovfx struct {
+	*archiveFlag
+
+	Path string
+}
+
+func init() {
+	cli.Register("import.ovf", &ovfx{})
+}
+
+func (cmd *ovfx) Register(ctx context.Context, f *flag.FlagSet) {
+	cmd.archiveFlag, ctx = &archiveFlag{}, ctx
+	cmd.archiveFlag.Register(ctx, f)
+}
+
+func (cmd *ovfx) Process(ctx context.Context) error {
+	return cmd.archiveFlag.Process(ctx)
+}
+
+func (cmd *ovfx) Usage() string {
+	return "PATH_TO_OVF"
+}
+
+func (cmd *ovfx) Description() string {
+	return `Deploy VM or vApp from an OVF descriptor.
+
+PATH_TO_OVF is a local file path to an .ovf file.
+
+Examples:
+  govc import.ovf -name my-vm vm.ovf
+  govc import.ovf -net "VM Network:dvportgroup-1" vm.ovf`
+}
+
+func (cmd *ovfx) Run(ctx context.Context, f *flag.FlagSet) error {
+	if f.NArg() != 1 {
+		return flag.ErrHelp
+	}
+	cmd.Path = f.Arg(0)
+
+	imp, err := cmd.Importer(ctx)
+	if err != nil {
+		return err
+	}
+
+	archive := &importer.FileArchive{Path: cmd.Path}
+
+	descriptor := path.Base(cmd.Path)
+	if cmd.Options.Name == nil {
+		name := strings.TrimSuffix(descriptor, path.Ext(descriptor))
+		cmd.Options.Name = &name
+	}
+
+	ref, err := imp.Import(ctx, archive, descriptor, cmd.Options)
+	if err != nil {
+		return err
+	}
+
+	return cmd.WriteResult(importResult{ref.Value})
+}
+
+type importResult struct {
+	Moref string `json:"moref"`
+}
+
+func (r importResult) Write(w io.Writer) error {
+	_, err := fmt.Fprintln(w, r.Moref)
+	return err
+}
@@ -0,0 +1,214 @@
+/*
+Copyright (c) 2024-2024 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/vmware/govmomi/govc/cli"
+	"github.com/vmware/govmomi/govc/flags"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/pbm"
+	pbmtypes "github.com/vmware/govmomi/pbm/types"
+	"github.com/vmware/govmomi/vapi/namespace"
+	"github.com/vmware/govmomi/vapi/rest"
+)
+
+type capabilityLs struct {
+	*flags.DatacenterFlag
+}
+
+func init() {
+	cli.Register("storage.capability.ls", &capabilityLs{})
+}
+
+func (cmd *capabilityLs) Register(ctx context.Context, f *flag.FlagSet) {
+	cmd.DatacenterFlag, ctx = flags.NewDatacenterFlag(ctx)
+	cmd.DatacenterFlag.Register(ctx, f)
+}
+
+func (cmd *capabilityLs) Description() string {
+	return `List the data service capabilities of each storage policy visible to this session.
+
+For every PBM storage profile, reports whether it supports snapshots,
+clones and online expansion, whether it encrypts data, which topology keys
+it constrains placement to, which datastores currently satisfy it, and
+whether it may be consumed by VM Service / vSphere Namespaces. This is the
+vSphere analog of how CSI StorageClassCapabilities are surfaced in the
+Kubernetes ecosystem, and is meant to answer at a glance which -storage
+profile ID a namespace command (see govc namespace.*) should reference.
+
+Examples:
+  govc storage.capability.ls
+  govc storage.capability.ls -json`
+}
+
+// Report is the normalized capability set and datastore match for a single
+// PBM storage profile.
+type Report struct {
+	ID                string   `json:"id"`
+	Name              string   `json:"name"`
+	Snapshot          bool     `json:"snapshot"`
+	Clone             bool     `json:"clone"`
+	Expansion         bool     `json:"expansion"`
+	Encryption        bool     `json:"encryption"`
+	Topology          []string `json:"topology,omitempty"`
+	Datastores        []string `json:"datastores,omitempty"`
+	VMServiceEligible bool     `json:"vmServiceEligible"`
+}
+
+func (cmd *capabilityLs) Run(ctx context.Context, f *flag.FlagSet) error {
+	c, err := cmd.Client()
+	if err != nil {
+		return err
+	}
+
+	pc, err := pbm.NewClient(ctx, c)
+	if err != nil {
+		return err
+	}
+
+	ids, err := pc.QueryProfile(ctx, pbmtypes.PbmProfileResourceTypeEnumSTORAGE)
+	if err != nil {
+		return err
+	}
+
+	profiles, err := pc.RetrieveContent(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	finder, err := cmd.Finder()
+	if err != nil {
+		return err
+	}
+
+	dss, err := finder.DatastoreList(ctx, "*")
+	if err != nil {
+		return err
+	}
+
+	hubs := make([]pbmtypes.PbmPlacementHub, 0, len(dss))
+	dsName := make(map[string]string, len(dss))
+	for _, ds := range dss {
+		ref := ds.Reference()
+		hubs = append(hubs, pbmtypes.PbmPlacementHub{HubType: ref.Type, HubId: ref.Value})
+		dsName[ref.Value] = ds.Name()
+	}
+
+	clusters, err := finder.ClusterComputeResourceList(ctx, "*")
+	if err != nil {
+		return err
+	}
+
+	rc, err := cmd.RestClient()
+	if err != nil {
+		return err
+	}
+
+	eligible, err := namespaceEligibleProfiles(ctx, rc, clusters)
+	if err != nil {
+		return err
+	}
+
+	var reports []Report
+
+	for _, p := range profiles {
+		base := p.GetPbmProfile()
+
+		r := Report{
+			ID:                base.ProfileId.UniqueId,
+			Name:              base.Name,
+			VMServiceEligible: eligible[base.ProfileId.UniqueId],
+		}
+
+		caps := pbm.ParseCapability(p)
+		r.Snapshot = caps.Snapshot
+		r.Clone = caps.Clone
+		r.Expansion = caps.Expansion
+		r.Encryption = caps.Encryption
+		r.Topology = caps.Topology
+
+		compatible, err := pc.CheckCompatibility(ctx, hubs, base.ProfileId)
+		if err != nil {
+			return fmt.Errorf("storage.capability.ls: checking compatibility for profile %q: %w", base.Name, err)
+		}
+		for _, hub := range compatible {
+			if name, ok := dsName[hub.Hub.HubId]; ok {
+				r.Datastores = append(r.Datastores, name)
+			}
+		}
+		sort.Strings(r.Datastores)
+
+		reports = append(reports, r)
+	}
+
+	sort.Slice(reports, func(i, j int) bool { return reports[i].Name < reports[j].Name })
+
+	return cmd.WriteResult(capabilityResult(reports))
+}
+
+// namespaceEligibleProfiles cross-references the namespace-management
+// compatible-storage-policies listing for each cluster, rather than
+// guessing eligibility from a profile's display name: a profile is
+// eligible for VM Service / vSphere Namespace consumption if any cluster
+// reports it compatible.
+func namespaceEligibleProfiles(ctx context.Context, rc *rest.Client, clusters []*object.ClusterComputeResource) (map[string]bool, error) {
+	eligible := make(map[string]bool)
+
+	m := namespace.NewManager(rc)
+
+	for _, cl := range clusters {
+		ids, err := m.ListCompatibleStoragePolicies(ctx, cl.Reference().Value)
+		if err != nil {
+			return nil, fmt.Errorf("listing namespace-compatible storage policies for cluster %q: %w", cl.Name(), err)
+		}
+		for _, id := range ids {
+			eligible[id] = true
+		}
+	}
+
+	return eligible, nil
+}
+
+type capabilityResult []Report
+
+func (r capabilityResult) Write(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 2, 0, 2, ' ', 0)
+
+	fmt.Fprintln(tw, "Name\tSnapshot\tClone\tExpansion\tEncryption\tNamespace\tDatastores")
+	for _, p := range r {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			p.Name,
+			strconv.FormatBool(p.Snapshot),
+			strconv.FormatBool(p.Clone),
+			strconv.FormatBool(p.Expansion),
+			strconv.FormatBool(p.Encryption),
+			strconv.FormatBool(p.VMServiceEligible),
+			strings.Join(p.Datastores, ","),
+		)
+	}
+
+	return tw.Flush()
+}
@@ -0,0 +1,111 @@
+/*
+Copyright (c) 2024-2024 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package check
+
+import (
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+// Category buckets a CheckResult fault by the kind of incompatibility it
+// represents, so a caller placing a VM across many candidate hosts can
+// summarize *why* each one failed instead of printing every fault string.
+type Category string
+
+const (
+	CategoryNetwork   Category = "network"
+	CategoryStorage   Category = "storage"
+	CategoryCPU       Category = "cpu"
+	CategoryLicensing Category = "licensing"
+	CategoryGeneric   Category = "generic"
+)
+
+// Classify buckets every warning and error fault in r by Category,
+// inspecting the concrete type behind each LocalizedMethodFault.Fault.
+func Classify(r types.CheckResult) map[Category][]types.LocalizedMethodFault {
+	out := make(map[Category][]types.LocalizedMethodFault)
+
+	classifyInto := func(faults []types.LocalizedMethodFault) {
+		for _, f := range faults {
+			c := classifyFault(f.Fault)
+			out[c] = append(out[c], f)
+		}
+	}
+
+	classifyInto(r.Warning)
+	classifyInto(r.Error)
+
+	return out
+}
+
+func classifyFault(fault types.BaseMethodFault) Category {
+	switch fault.(type) {
+	case *types.VirtualEthernetCardNotSupported,
+		*types.VmConfigIncompatibleForFaultTolerance,
+		*types.NetworksMayNotBeTheSame,
+		*types.NetworkCopyFault:
+		return CategoryNetwork
+
+	case *types.InsufficientDisksizeFault,
+		*types.DatastoreNotWritableOnHost,
+		*types.NoDisksToCustomize,
+		*types.DiskNotSupported:
+		return CategoryStorage
+
+	case *types.CpuIncompatible,
+		*types.CpuIncompatible1ECX,
+		*types.CpuIncompatible81EDX,
+		*types.FeatureRequirementsNotMet:
+		return CategoryCPU
+
+	case *types.NoLicenseEvent,
+		*types.LicenseAssignmentFailed,
+		*types.InvalidEditionEvent:
+		return CategoryLicensing
+
+	default:
+		return CategoryGeneric
+	}
+}
+
+// filterCategories removes any fault from r.Warning/r.Error whose
+// classification is not in categories. A nil or empty categories keeps r
+// unchanged.
+func filterCategories(r types.CheckResult, categories []string) types.CheckResult {
+	if len(categories) == 0 {
+		return r
+	}
+
+	keep := make(map[Category]bool, len(categories))
+	for _, c := range categories {
+		keep[Category(c)] = true
+	}
+
+	match := func(faults []types.LocalizedMethodFault) []types.LocalizedMethodFault {
+		var out []types.LocalizedMethodFault
+		for _, f := range faults {
+			if keep[classifyFault(f.Fault)] {
+				out = append(out, f)
+			}
+		}
+		return out
+	}
+
+	r.Warning = match(r.Warning)
+	r.Error = match(r.Error)
+
+	return r
+}
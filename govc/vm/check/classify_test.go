@@ -0,0 +1,74 @@
+/*
+Copyright (c) 2024-2024 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package check
+
+import (
+	"testing"
+
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+func TestClassifyFault(t *testing.T) {
+	tests := []struct {
+		name  string
+		fault types.BaseMethodFault
+		want  Category
+	}{
+		{"network", &types.VirtualEthernetCardNotSupported{}, CategoryNetwork},
+		{"storage", &types.InsufficientDisksizeFault{}, CategoryStorage},
+		{"cpu", &types.CpuIncompatible{}, CategoryCPU},
+		{"licensing", &types.NoLicenseEvent{}, CategoryLicensing},
+		{"generic", &types.NotSupported{}, CategoryGeneric},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := classifyFault(tt.fault); got != tt.want {
+				t.Errorf("classifyFault(%T) = %q, want %q", tt.fault, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterCategories(t *testing.T) {
+	r := types.CheckResult{
+		Warning: []types.LocalizedMethodFault{
+			{Fault: &types.VirtualEthernetCardNotSupported{}, LocalizedMessage: "network fault"},
+			{Fault: &types.InsufficientDisksizeFault{}, LocalizedMessage: "storage fault"},
+		},
+		Error: []types.LocalizedMethodFault{
+			{Fault: &types.CpuIncompatible{}, LocalizedMessage: "cpu fault"},
+		},
+	}
+
+	t.Run("no filter", func(t *testing.T) {
+		got := filterCategories(r, nil)
+		if len(got.Warning) != 2 || len(got.Error) != 1 {
+			t.Errorf("expected unfiltered result, got %+v", got)
+		}
+	})
+
+	t.Run("storage only", func(t *testing.T) {
+		got := filterCategories(r, []string{string(CategoryStorage)})
+		if len(got.Warning) != 1 || got.Warning[0].LocalizedMessage != "storage fault" {
+			t.Errorf("expected only the storage warning to survive, got %+v", got.Warning)
+		}
+		if len(got.Error) != 0 {
+			t.Errorf("expected no errors to survive a storage-only filter, got %+v", got.Error)
+		}
+	})
+}
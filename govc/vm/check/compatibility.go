@@ -0,0 +1,121 @@
+/*
+Copyright (c) 2024-2024 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package check
+
+import (
+	"context"
+	"flag"
+
+	"github.com/vmware/govmomi/govc/cli"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+// compatibility implements `govc vm.check.compatibility`, trying the VM
+// against every HOST argument, collecting each host's result as it
+// responds and printing them all once every check has finished, instead of
+// failing the whole batch on the first incompatible host.
+type compatibility struct {
+	*checkFlag
+}
+
+func init() {
+	cli.Register("vm.check.compatibility", &compatibility{})
+}
+
+func (cmd *compatibility) Register(ctx context.Context, f *flag.FlagSet) {
+	cmd.checkFlag = &checkFlag{}
+	cmd.checkFlag.Register(ctx, f)
+}
+
+func (cmd *compatibility) Process(ctx context.Context) error {
+	return cmd.checkFlag.Process(ctx)
+}
+
+func (cmd *compatibility) Usage() string {
+	return "HOST..."
+}
+
+func (cmd *compatibility) Description() string {
+	return `Test VM compatibility with one or more hosts.
+
+Unlike a single CheckCompatibility call, this tries every HOST given and
+reports which ones failed and why, rather than stopping at the first
+incompatible host. Use -parallel to issue several checks concurrently when
+testing against many candidate hosts, and -category to only show faults of
+a given kind (network, storage, cpu, licensing, generic).
+
+Examples:
+  govc vm.check.compatibility -vm my-vm esx-1 esx-2 esx-3
+  govc vm.check.compatibility -vm my-vm -parallel 8 -category storage esx-*`
+}
+
+func (cmd *compatibility) Run(ctx context.Context, f *flag.FlagSet) error {
+	if f.NArg() == 0 {
+		return flag.ErrHelp
+	}
+	if cmd.Machine == nil {
+		return flag.ErrHelp
+	}
+
+	finder, err := cmd.HostSystemFlag.Finder()
+	if err != nil {
+		return err
+	}
+
+	var targets []object.Target
+	for _, arg := range f.Args() {
+		hosts, err := finder.HostSystemList(ctx, arg)
+		if err != nil {
+			return err
+		}
+		for _, h := range hosts {
+			ref := h.Reference()
+			targets = append(targets, object.Target{Host: &ref})
+		}
+	}
+
+	checker, err := cmd.compatChecker()
+	if err != nil {
+		return err
+	}
+
+	var results []types.CheckResult
+	var firstErr error
+
+	// Drain the channel fully even after the first error: the per-target
+	// goroutines in checkTargets block sending until we receive, so
+	// returning early here would leak one goroutine per target still in
+	// flight.
+	for tr := range checker.CheckCompatibilityForTargets(ctx, *cmd.Machine, targets, cmd.Test, cmd.Parallel) {
+		if tr.Err != nil {
+			if firstErr == nil {
+				firstErr = tr.Err
+			}
+			continue
+		}
+		for _, r := range tr.Result {
+			results = append(results, filterCategories(r, cmd.Category))
+		}
+	}
+
+	if firstErr != nil {
+		return firstErr
+	}
+
+	return cmd.result(ctx, results)
+}
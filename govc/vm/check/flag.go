@@ -41,6 +41,9 @@ type checkFlag struct {
 	Machine, Host, Pool *types.ManagedObjectReference
 
 	Test []string
+
+	Parallel int
+	Category flags.StringList
 }
 
 func (cmd *checkFlag) Register(ctx context.Context, f *flag.FlagSet) {
@@ -50,6 +53,9 @@ func (cmd *checkFlag) Register(ctx context.Context, f *flag.FlagSet) {
 	cmd.HostSystemFlag.Register(ctx, f)
 	cmd.ResourcePoolFlag, ctx = flags.NewResourcePoolFlag(ctx)
 	cmd.ResourcePoolFlag.Register(ctx, f)
+
+	f.IntVar(&cmd.Parallel, "parallel", 1, "Number of concurrent checks to run against candidate targets")
+	f.Var(&cmd.Category, "category", "Only report faults in this category (network, storage, cpu, licensing, generic)")
 }
 
 func (cmd *checkFlag) Process(ctx context.Context) error {
@@ -161,7 +167,30 @@ func (res *checkResult) Write(w io.Writer) error {
 			}
 			fmt.Fprintf(tw, "%s:\t%s\n", f.name, val)
 		}
+
+		if cats := categorySummary(r); cats != "" {
+			fmt.Fprintf(tw, "Categories:\t%s\n", cats)
+		}
 	}
 
 	return tw.Flush()
 }
+
+// categoryOrder fixes the iteration order of a Classify result so repeated
+// runs against the same CheckResult produce identical output.
+var categoryOrder = []Category{CategoryNetwork, CategoryStorage, CategoryCPU, CategoryLicensing, CategoryGeneric}
+
+// categorySummary renders the Classify breakdown of r as "category=count"
+// pairs, e.g. "network=2, storage=1".
+func categorySummary(r types.CheckResult) string {
+	buckets := Classify(r)
+
+	var parts []string
+	for _, cat := range categoryOrder {
+		if faults := buckets[cat]; len(faults) > 0 {
+			parts = append(parts, fmt.Sprintf("%s=%d", cat, len(faults)))
+		}
+	}
+
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,123 @@
+/*
+Copyright (c) 2024-2024 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package check
+
+import (
+	"context"
+	"flag"
+
+	"github.com/vmware/govmomi/govc/cli"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+// provisioning implements `govc vm.check.provisioning`, validating a
+// VirtualMachineConfigSpec (read as XML from stdin, see checkFlag.Spec)
+// against one or more candidate resource pools.
+type provisioning struct {
+	*checkFlag
+}
+
+func init() {
+	cli.Register("vm.check.provisioning", &provisioning{})
+}
+
+func (cmd *provisioning) Register(ctx context.Context, f *flag.FlagSet) {
+	cmd.checkFlag = &checkFlag{}
+	cmd.checkFlag.Register(ctx, f)
+}
+
+func (cmd *provisioning) Process(ctx context.Context) error {
+	return cmd.checkFlag.Process(ctx)
+}
+
+func (cmd *provisioning) Usage() string {
+	return "POOL..."
+}
+
+func (cmd *provisioning) Description() string {
+	return `Test a VirtualMachineConfigSpec against one or more resource pools.
+
+The spec is read as XML from stdin, the same format used by
+govc object.method -xml for a VirtualMachineConfigSpec. As with
+vm.check.compatibility, every POOL given is tried and the results are
+printed once every pool has responded; use -parallel to check many pools
+concurrently and -category to filter the faults shown.
+
+Examples:
+  govc vm.check.provisioning -vm my-vm < spec.xml
+  govc vm.check.provisioning -vm my-vm -parallel 4 cluster-1/Resources cluster-2/Resources < spec.xml`
+}
+
+func (cmd *provisioning) Run(ctx context.Context, f *flag.FlagSet) error {
+	if f.NArg() == 0 {
+		return flag.ErrHelp
+	}
+	if cmd.Machine == nil {
+		return flag.ErrHelp
+	}
+
+	var spec types.VirtualMachineConfigSpec
+	if err := cmd.Spec(&spec); err != nil {
+		return err
+	}
+
+	finder, err := cmd.ResourcePoolFlag.Finder()
+	if err != nil {
+		return err
+	}
+
+	var targets []object.Target
+	for _, arg := range f.Args() {
+		pool, err := finder.ResourcePool(ctx, arg)
+		if err != nil {
+			return err
+		}
+		ref := pool.Reference()
+		targets = append(targets, object.Target{Pool: &ref})
+	}
+
+	checker, err := cmd.provChecker()
+	if err != nil {
+		return err
+	}
+
+	var results []types.CheckResult
+	var firstErr error
+
+	// Drain the channel fully even after the first error: the per-target
+	// goroutines in checkTargets block sending until we receive, so
+	// returning early here would leak one goroutine per target still in
+	// flight.
+	for tr := range checker.CheckProvisioningForTargets(ctx, *cmd.Machine, &spec, targets, cmd.Test, cmd.Parallel) {
+		if tr.Err != nil {
+			if firstErr == nil {
+				firstErr = tr.Err
+			}
+			continue
+		}
+		for _, r := range tr.Result {
+			results = append(results, filterCategories(r, cmd.Category))
+		}
+	}
+
+	if firstErr != nil {
+		return firstErr
+	}
+
+	return cmd.result(ctx, results)
+}
@@ -0,0 +1,106 @@
+/*
+Copyright (c) 2024-2024 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+	"sync"
+
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+// Target is a candidate placement for a compatibility or provisioning check:
+// some combination of host, resource pool and datastore, depending on which
+// of those the check being run accepts. Callers only need to set the fields
+// relevant to the check they invoke.
+type Target struct {
+	Host      *types.ManagedObjectReference
+	Pool      *types.ManagedObjectReference
+	Datastore *types.ManagedObjectReference
+}
+
+// TargetResult pairs a Target with the outcome of checking it, so a
+// streaming caller can tell which candidate a CheckResult/error belongs to.
+type TargetResult struct {
+	Target Target
+	Result []types.CheckResult
+	Err    error
+}
+
+// checkTargets runs check against each of targets with up to parallel
+// concurrent requests in flight, streaming results back as they arrive
+// rather than waiting for every target to finish. A parallel of 0 or 1
+// runs targets serially.
+//
+// This exists so govc vm.check.* and similar tools can try many hosts and
+// report progress/failures as they happen, instead of blocking until the
+// slowest candidate in the batch responds.
+func checkTargets(ctx context.Context, targets []Target, parallel int, check func(context.Context, Target) ([]types.CheckResult, error)) <-chan TargetResult {
+	out := make(chan TargetResult)
+
+	if parallel < 1 {
+		parallel = 1
+	}
+
+	go func() {
+		defer close(out)
+
+		sem := make(chan struct{}, parallel)
+		var wg sync.WaitGroup
+
+		for _, target := range targets {
+			target := target
+
+			wg.Add(1)
+			sem <- struct{}{}
+
+			go func() {
+				defer wg.Done()
+				defer func() { <-sem }()
+
+				res, err := check(ctx, target)
+				select {
+				case out <- TargetResult{Target: target, Result: res, Err: err}:
+				case <-ctx.Done():
+				}
+			}()
+		}
+
+		wg.Wait()
+	}()
+
+	return out
+}
+
+// CheckCompatibilityForTargets runs CheckCompatibility against each of
+// targets, streaming a TargetResult per target as it completes. Up to
+// parallel checks are issued concurrently (parallel <= 1 means serial).
+func (c VmCompatibilityChecker) CheckCompatibilityForTargets(ctx context.Context, vm types.ManagedObjectReference, targets []Target, testType []string, parallel int) <-chan TargetResult {
+	return checkTargets(ctx, targets, parallel, func(ctx context.Context, t Target) ([]types.CheckResult, error) {
+		return c.CheckCompatibility(ctx, vm, t.Host, testType)
+	})
+}
+
+// CheckProvisioningForTargets runs CheckCompatibility against each of
+// targets (host and/or pool; Target.Datastore is not used), streaming a
+// TargetResult per target as it completes. Up to parallel checks are issued concurrently
+// (parallel <= 1 means serial).
+func (c VmProvisioningChecker) CheckProvisioningForTargets(ctx context.Context, vm types.ManagedObjectReference, spec *types.VirtualMachineConfigSpec, targets []Target, testType []string, parallel int) <-chan TargetResult {
+	return checkTargets(ctx, targets, parallel, func(ctx context.Context, t Target) ([]types.CheckResult, error) {
+		return c.CheckCompatibility(ctx, vm, t.Host, t.Pool, spec, testType)
+	})
+}
@@ -0,0 +1,51 @@
+/*
+Copyright (c) 2024-2024 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package object
+
+import (
+	"context"
+
+	"github.com/vmware/govmomi/vim25/methods"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+// QueryVirtualDiskInfoEx returns extended information about the virtual
+// disk chain rooted at name, including per-link capacity, thin-provisioning
+// and changed-block-tracking state. Unlike QueryVirtualDiskUuid, it does not
+// require the disk to be attached to a VM.
+//
+// includeParents controls whether the parent disks of a snapshot chain are
+// included in the result.
+func (m VirtualDiskManager) QueryVirtualDiskInfoEx(ctx context.Context, name string, dc *Datacenter, includeParents bool) ([]types.VirtualDiskInfo, error) {
+	req := types.QueryVirtualDiskInfoEx{
+		This:           m.Reference(),
+		Name:           name,
+		IncludeParents: includeParents,
+	}
+
+	if dc != nil {
+		ref := dc.Reference()
+		req.Datacenter = &ref
+	}
+
+	res, err := methods.QueryVirtualDiskInfoEx(ctx, m.Client(), &req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Returnval, nil
+}
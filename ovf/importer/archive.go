@@ -0,0 +1,138 @@
+/*
+Copyright (c) 2024-2024 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package importer
+
+import (
+	"archive/tar"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+)
+
+// Archive abstracts the container an OVF/OVA descriptor and its disks are
+// read from, so Importer can treat a directory of files (.ovf + .vmdk) and a
+// single .ova tar stream the same way.
+type Archive interface {
+	// Open returns a reader for the entry matching name along with its
+	// size. For a FileArchive, name is resolved relative to the archive's
+	// directory; for a TapeArchive it is matched against the base name of
+	// each tar entry.
+	Open(name string) (io.ReadCloser, int64, error)
+
+	// Resolve returns the actual entry name matching pattern, without
+	// opening it. For a FileArchive this is pattern itself; for a
+	// TapeArchive, where pattern may be a glob such as "*.ovf", it is the
+	// full matching tar entry name. Callers use this to derive a default
+	// entity name from the real descriptor filename instead of the glob.
+	Resolve(pattern string) (string, error)
+}
+
+// FileArchive reads OVF descriptors and disks from a directory on disk,
+// as produced by unpacking an .ova or exporting an OVF template directly.
+type FileArchive struct {
+	Path string
+}
+
+func (t *FileArchive) Open(name string) (io.ReadCloser, int64, error) {
+	name = filepath.Join(filepath.Dir(t.Path), name)
+
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	s, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, 0, err
+	}
+
+	return f, s.Size(), nil
+}
+
+func (t *FileArchive) Resolve(pattern string) (string, error) {
+	return pattern, nil
+}
+
+// TapeArchive reads OVF descriptors and disks out of a .ova tar file without
+// needing to unpack it first.
+type TapeArchive struct {
+	Path string
+}
+
+type tapeArchiveEntry struct {
+	io.Reader
+	f *os.File
+}
+
+func (t *tapeArchiveEntry) Close() error {
+	return t.f.Close()
+}
+
+func (t *TapeArchive) Open(name string) (io.ReadCloser, int64, error) {
+	f, err := os.Open(t.Path)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	r := tar.NewReader(f)
+
+	for {
+		h, err := r.Next()
+		if err != nil {
+			f.Close()
+			return nil, 0, err
+		}
+
+		matched, err := filepath.Match(name, path.Base(h.Name))
+		if err != nil {
+			f.Close()
+			return nil, 0, err
+		}
+
+		if matched {
+			return &tapeArchiveEntry{r, f}, h.Size, nil
+		}
+	}
+}
+
+func (t *TapeArchive) Resolve(pattern string) (string, error) {
+	f, err := os.Open(t.Path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	r := tar.NewReader(f)
+
+	for {
+		h, err := r.Next()
+		if err != nil {
+			return "", err
+		}
+
+		matched, err := filepath.Match(pattern, path.Base(h.Name))
+		if err != nil {
+			return "", err
+		}
+
+		if matched {
+			return h.Name, nil
+		}
+	}
+}
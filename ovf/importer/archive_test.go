@@ -0,0 +1,123 @@
+/*
+Copyright (c) 2024-2024 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package importer
+
+import (
+	"archive/tar"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func newTestOVA(t *testing.T) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	name := filepath.Join(dir, "vm.ova")
+
+	f, err := os.Create(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	w := tar.NewWriter(f)
+	defer w.Close()
+
+	files := map[string]string{
+		"vm.ovf":  "<Envelope/>",
+		"vm.vmdk": "disk-contents",
+		"vm.mf":   "SHA256(vm.ovf)=abc",
+	}
+
+	for name, content := range files {
+		hdr := &tar.Header{Name: name, Size: int64(len(content)), Mode: 0644}
+		if err := w.WriteHeader(hdr); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return name
+}
+
+func TestTapeArchiveResolve(t *testing.T) {
+	archive := &TapeArchive{Path: newTestOVA(t)}
+
+	name, err := archive.Resolve("*.ovf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name != "vm.ovf" {
+		t.Errorf("Resolve(*.ovf) = %q, want %q", name, "vm.ovf")
+	}
+
+	if _, err := archive.Resolve("*.nope"); err == nil {
+		t.Error("expected an error resolving a non-matching pattern")
+	}
+}
+
+func TestTapeArchiveOpen(t *testing.T) {
+	archive := &TapeArchive{Path: newTestOVA(t)}
+
+	rc, size, err := archive.Open("vm.vmdk")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "disk-contents" {
+		t.Errorf("Open(vm.vmdk) contents = %q, want %q", data, "disk-contents")
+	}
+	if size != int64(len("disk-contents")) {
+		t.Errorf("Open(vm.vmdk) size = %d, want %d", size, len("disk-contents"))
+	}
+}
+
+func TestFileArchiveOpen(t *testing.T) {
+	dir := t.TempDir()
+	ovf := filepath.Join(dir, "vm.ovf")
+	if err := os.WriteFile(ovf, []byte("<Envelope/>"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "vm.vmdk"), []byte("disk"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	archive := &FileArchive{Path: ovf}
+
+	rc, size, err := archive.Open("vm.vmdk")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rc.Close()
+
+	if size != 4 {
+		t.Errorf("Open(vm.vmdk) size = %d, want 4", size)
+	}
+
+	if name, err := archive.Resolve("vm.vmdk"); err != nil || name != "vm.vmdk" {
+		t.Errorf("Resolve(vm.vmdk) = (%q, %v), want (vm.vmdk, nil)", name, err)
+	}
+}
@@ -0,0 +1,163 @@
+/*
+Copyright (c) 2024-2024 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package importer implements OVF/OVA deployment as a reusable Go API. It
+// factors out the archive traversal, import-spec generation and NFC upload
+// logic that the govc import.ovf/import.ova commands have historically
+// implemented inline, so that other programs can deploy an OVF/OVA into
+// vSphere without shelling out to govc.
+package importer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/vmware/govmomi/nfc"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/progress"
+	"github.com/vmware/govmomi/vim25/soap"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+// Importer deploys an OVF or OVA package into vSphere. It mirrors the shape
+// of object.VmProvisioningChecker: a small stateful wrapper around a
+// vim25.Client plus the placement objects a deploy needs, rather than a
+// single do-everything function.
+type Importer struct {
+	Client *vim25.Client
+
+	Datacenter   *object.Datacenter
+	ResourcePool *object.ResourcePool
+	Datastore    *object.Datastore
+	Folder       *object.Folder
+	Host         *object.HostSystem
+
+	// Log receives progress.Report updates as disks upload. A nil Log is
+	// valid and is treated as a no-op sink.
+	Log progress.Sinker
+}
+
+// NewImporter returns an Importer bound to c. Callers must set ResourcePool
+// and Datastore (and typically Folder) before calling Import or CreateSpec.
+func NewImporter(c *vim25.Client) *Importer {
+	return &Importer{Client: c}
+}
+
+// Spec wraps the result of resolving an OVF descriptor against Options.
+type Spec struct {
+	*types.OvfCreateImportSpecResult
+}
+
+// Error returns the first fault reported while building the import spec, if
+// any.
+func (s *Spec) Error() error {
+	if s.OvfCreateImportSpecResult == nil || len(s.OvfCreateImportSpecResult.Error) == 0 {
+		return nil
+	}
+
+	return errors.New(s.OvfCreateImportSpecResult.Error[0].LocalizedMessage)
+}
+
+// CreateSpec resolves ovfDescriptor (the contents of the package's .ovf
+// file) against opts into a Spec, ready to hand to ImportVApp.
+func (imp *Importer) CreateSpec(ctx context.Context, ovfDescriptor string, opts Options) (*Spec, error) {
+	if imp.ResourcePool == nil {
+		return nil, errors.New("importer: ResourcePool is required")
+	}
+
+	m := object.NewOvfManager(imp.Client)
+
+	params := opts.NewImportSpecParams(opts.Path)
+
+	res, err := m.CreateImportSpec(ctx, ovfDescriptor, imp.ResourcePool, imp.Datastore, params)
+	if err != nil {
+		return nil, err
+	}
+	if res == nil {
+		return nil, fmt.Errorf("importer: CreateImportSpec returned no result for %q", opts.Path)
+	}
+
+	return &Spec{res}, nil
+}
+
+// Import deploys descriptorName (the name of the .ovf/.ovf-resident entry
+// within archive) using opts, driving CreateImportSpec, ImportVApp and the
+// resulting NFC lease upload. It returns the reference of the VM or vApp
+// root created by the import.
+func (imp *Importer) Import(ctx context.Context, archive Archive, descriptorName string, opts Options) (*types.ManagedObjectReference, error) {
+	opts.Path = descriptorName
+
+	f, _, err := archive.Open(descriptorName)
+	if err != nil {
+		return nil, fmt.Errorf("importer: opening descriptor %q: %w", descriptorName, err)
+	}
+	desc, err := io.ReadAll(f)
+	f.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	spec, err := imp.CreateSpec(ctx, string(desc), opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := spec.Error(); err != nil {
+		return nil, err
+	}
+
+	lease, err := imp.ResourcePool.ImportVApp(ctx, spec.ImportSpec, imp.Folder, imp.Host)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := lease.Wait(ctx, spec.FileItem)
+	if err != nil {
+		return nil, err
+	}
+
+	u := lease.StartUpdater(ctx, info)
+	defer u.Done()
+
+	for _, item := range info.Items {
+		if err := imp.upload(ctx, lease, archive, item); err != nil {
+			_ = lease.Abort(ctx, nil)
+			return nil, err
+		}
+	}
+
+	if err := lease.Complete(ctx); err != nil {
+		return nil, err
+	}
+
+	entity := info.Entity
+	return &entity, nil
+}
+
+func (imp *Importer) upload(ctx context.Context, lease *nfc.Lease, archive Archive, item nfc.FileItem) error {
+	f, size, err := archive.Open(item.Path)
+	if err != nil {
+		return fmt.Errorf("importer: opening disk %q: %w", item.Path, err)
+	}
+	defer f.Close()
+
+	return lease.Upload(ctx, item, f, soap.Upload{
+		ContentLength: size,
+		Progress:      imp.Log,
+	})
+}
@@ -0,0 +1,95 @@
+/*
+Copyright (c) 2024-2024 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package importer
+
+import (
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+// NetworkMapping maps an OVF network name to an inventory network.
+type NetworkMapping struct {
+	Name    string
+	Network string
+}
+
+// PropertyMapping sets the value of an OVF environment property by key.
+type PropertyMapping struct {
+	Key   string
+	Value string
+}
+
+// Options controls how Importer resolves an OVF/OVA descriptor into a
+// CreateImportSpec call. It is the library equivalent of the flags bound by
+// the govc import.ovf/import.ova commands. Importer.Import does not read
+// Annotation, PowerOn, InjectOvfEnv, WaitForIP or MarkAsTemplate: the
+// imported entity is left as the ImportVApp lease created it.
+type Options struct {
+	// Path of the descriptor being imported, relative to the archive.
+	// Importer.Import sets this; callers do not normally set it directly.
+	Path string
+
+	Name *string
+
+	Deployment         string
+	IPAllocationPolicy string
+	IPProtocol         string
+	Annotation         string
+
+	NetworkMapping  []NetworkMapping
+	PropertyMapping []PropertyMapping
+
+	PowerOn        bool
+	InjectOvfEnv   bool
+	WaitForIP      bool
+	MarkAsTemplate bool
+}
+
+// NewImportSpecParams converts o into the types.OvfCreateImportSpecParams
+// consumed by object.OvfManager.CreateImportSpec, using entityName as the
+// VM/vApp name unless o.Name overrides it.
+func (o Options) NewImportSpecParams(entityName string) types.OvfCreateImportSpecParams {
+	if o.Name != nil {
+		entityName = *o.Name
+	}
+
+	p := types.OvfCreateImportSpecParams{
+		OvfManagerCommonParams: types.OvfManagerCommonParams{
+			DeploymentOption: o.Deployment,
+			Locale:           "US",
+		},
+		EntityName:         entityName,
+		IpAllocationPolicy: o.IPAllocationPolicy,
+		IpProtocol:         o.IPProtocol,
+		PropertyMapping:    make([]types.KeyValue, 0, len(o.PropertyMapping)),
+	}
+
+	for _, n := range o.NetworkMapping {
+		p.NetworkMapping = append(p.NetworkMapping, types.OvfNetworkMapping{
+			Name:    n.Name,
+			Network: types.ManagedObjectReference{Type: "Network", Value: n.Network},
+		})
+	}
+
+	for _, prop := range o.PropertyMapping {
+		p.PropertyMapping = append(p.PropertyMapping, types.KeyValue{
+			Key:   prop.Key,
+			Value: prop.Value,
+		})
+	}
+
+	return p
+}
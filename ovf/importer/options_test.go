@@ -0,0 +1,73 @@
+/*
+Copyright (c) 2024-2024 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package importer
+
+import (
+	"testing"
+
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+func TestNewImportSpecParamsDefaultName(t *testing.T) {
+	o := Options{Deployment: "small"}
+
+	p := o.NewImportSpecParams("vm")
+
+	if p.EntityName != "vm" {
+		t.Errorf("EntityName = %q, want %q", p.EntityName, "vm")
+	}
+	if p.DeploymentOption != "small" {
+		t.Errorf("DeploymentOption = %q, want %q", p.DeploymentOption, "small")
+	}
+}
+
+func TestNewImportSpecParamsNameOverride(t *testing.T) {
+	name := "my-vm"
+	o := Options{Name: &name}
+
+	p := o.NewImportSpecParams("vm")
+
+	if p.EntityName != "my-vm" {
+		t.Errorf("EntityName = %q, want %q", p.EntityName, "my-vm")
+	}
+}
+
+func TestNewImportSpecParamsMappings(t *testing.T) {
+	o := Options{
+		NetworkMapping: []NetworkMapping{
+			{Name: "VM Network", Network: "dvportgroup-1"},
+		},
+		PropertyMapping: []PropertyMapping{
+			{Key: "guestinfo.hostname", Value: "host1"},
+		},
+	}
+
+	p := o.NewImportSpecParams("vm")
+
+	if len(p.NetworkMapping) != 1 {
+		t.Fatalf("NetworkMapping has %d entries, want 1", len(p.NetworkMapping))
+	}
+	nm := p.NetworkMapping[0]
+	if nm.Name != "VM Network" || nm.Network.Value != "dvportgroup-1" || nm.Network.Type != "Network" {
+		t.Errorf("NetworkMapping[0] = %+v, unexpected", nm)
+	}
+
+	want := types.KeyValue{Key: "guestinfo.hostname", Value: "host1"}
+	if len(p.PropertyMapping) != 1 || p.PropertyMapping[0] != want {
+		t.Errorf("PropertyMapping = %+v, want [%+v]", p.PropertyMapping, want)
+	}
+}
@@ -0,0 +1,99 @@
+/*
+Copyright (c) 2024-2024 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pbm
+
+import (
+	"github.com/vmware/govmomi/pbm/types"
+)
+
+// Well-known VASA capability/property IDs normalized into Capability.
+// Storage providers are not required to advertise all of them; a profile
+// that references none of these just reports an all-false Capability.
+const (
+	capabilitySnapshot   = "com.vmware.storageprofile.dataservice.snapshot"
+	capabilityClone      = "com.vmware.storageprofile.dataservice.clone"
+	capabilityExpansion  = "com.vmware.storageprofile.dataservice.expansion"
+	capabilityEncryption = "com.vmware.storageprofile.dataservice.encryption"
+	capabilityTopology   = "com.vmware.storageprofile.topology"
+)
+
+// Capability is a normalized view of the data services a PBM storage
+// profile advertises, analogous to how CSI StorageClassCapabilities
+// surface storage features in the Kubernetes ecosystem.
+type Capability struct {
+	Snapshot   bool
+	Clone      bool
+	Expansion  bool
+	Encryption bool
+
+	// Topology lists the topology keys (e.g. zone/region identifiers) the
+	// profile constrains placement to, if any.
+	Topology []string
+}
+
+// ParseCapability normalizes the capability-based constraints of profile
+// into a Capability. Profiles that are not capability-based (e.g.
+// tag-based profiles) report the zero value.
+func ParseCapability(profile types.BasePbmProfile) Capability {
+	var result Capability
+
+	cbp, ok := profile.(*types.PbmCapabilityProfile)
+	if !ok || cbp.Constraints == nil {
+		return result
+	}
+
+	sub, ok := cbp.Constraints.(*types.PbmCapabilitySubProfileConstraints)
+	if !ok {
+		return result
+	}
+
+	for _, sp := range sub.SubProfiles {
+		for _, c := range sp.Capability {
+			for _, inst := range c.Constraint {
+				for _, prop := range inst.PropertyInstance {
+					switch c.Id.Id {
+					case capabilitySnapshot:
+						result.Snapshot = truthy(prop.Value)
+					case capabilityClone:
+						result.Clone = truthy(prop.Value)
+					case capabilityExpansion:
+						result.Expansion = truthy(prop.Value)
+					case capabilityEncryption:
+						result.Encryption = truthy(prop.Value)
+					case capabilityTopology:
+						if s, ok := prop.Value.(string); ok {
+							result.Topology = append(result.Topology, s)
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return result
+}
+
+func truthy(v any) bool {
+	switch t := v.(type) {
+	case bool:
+		return t
+	case string:
+		return t == "true" || t == "1"
+	default:
+		return v != nil
+	}
+}
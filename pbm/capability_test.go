@@ -0,0 +1,102 @@
+/*
+Copyright (c) 2024-2024 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pbm
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/vmware/govmomi/pbm/types"
+)
+
+func newProfile(id string, value any) *types.PbmCapabilityProfile {
+	return &types.PbmCapabilityProfile{
+		Constraints: &types.PbmCapabilitySubProfileConstraints{
+			SubProfiles: []types.PbmCapabilitySubProfile{
+				{
+					Capability: []types.PbmCapabilityInstance{
+						{
+							Id: types.PbmCapabilityMetadataUniqueId{Id: id},
+							Constraint: []types.PbmCapabilityConstraintInstance{
+								{
+									PropertyInstance: []types.PbmCapabilityPropertyInstance{
+										{Value: value},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestParseCapability(t *testing.T) {
+	tests := []struct {
+		name string
+		id   string
+		val  any
+		want Capability
+	}{
+		{"snapshot", capabilitySnapshot, true, Capability{Snapshot: true}},
+		{"clone string true", capabilityClone, "true", Capability{Clone: true}},
+		{"expansion false", capabilityExpansion, false, Capability{Expansion: false}},
+		{"encryption", capabilityEncryption, true, Capability{Encryption: true}},
+		{"topology", capabilityTopology, "zone-a", Capability{Topology: []string{"zone-a"}}},
+		{"unknown id", "com.vmware.unknown.capability", true, Capability{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseCapability(newProfile(tt.id, tt.val))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseCapability() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCapabilityNonCapabilityBased(t *testing.T) {
+	// A tag-based profile is not a *types.PbmCapabilityProfile at all.
+	got := ParseCapability(&types.PbmProfile{})
+	if !reflect.DeepEqual(got, Capability{}) {
+		t.Errorf("ParseCapability(tag-based profile) = %+v, want zero value", got)
+	}
+}
+
+func TestTruthy(t *testing.T) {
+	tests := []struct {
+		in   any
+		want bool
+	}{
+		{true, true},
+		{false, false},
+		{"true", true},
+		{"1", true},
+		{"false", false},
+		{"", false},
+		{nil, false},
+		{42, true},
+	}
+
+	for _, tt := range tests {
+		if got := truthy(tt.in); got != tt.want {
+			t.Errorf("truthy(%#v) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+/*
+Copyright (c) 2024-2024 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package progress
+
+import (
+	"fmt"
+	"time"
+)
+
+// LoggerFunc is the shape of a logging function such as log.Printf that
+// Logger can drive.
+type LoggerFunc func(format string, v ...any)
+
+// sizer is implemented by Report values that know the total number of bytes
+// being transferred. When a reported value implements it, Logger converts
+// percentage deltas into a byte rate; otherwise it only logs percentage and
+// ETA.
+type sizer interface {
+	Size() int64
+}
+
+// logInterval throttles how often Logger emits a line while reports are
+// still arriving, so a fast upload doesn't spam one line per chunk.
+const logInterval = time.Second
+
+// sampleWindow bounds the number of (time, percentage) samples kept for
+// rate smoothing; five samples is enough to damp jitter from bursty reads
+// without lagging behind a real trend change for more than a few seconds.
+const sampleWindow = 5
+
+// Logger returns a Sinker that consumes a Report stream and emits throttled,
+// human-readable lines of the form:
+//
+//	prefix: 42% (1.3 MiB/s, ETA 12s)
+//
+// to log, plus a final summary line when the report channel closes. It lets
+// library consumers (OVF import, OVA upload, VM clone) get reasonable
+// progress output without reimplementing the tabwriter/stderr dance each
+// govc command does today.
+func Logger(log LoggerFunc, prefix string) Sinker {
+	return &logSinker{log: log, prefix: prefix}
+}
+
+type logSinker struct {
+	log    LoggerFunc
+	prefix string
+}
+
+func (l *logSinker) Sink() chan<- Report {
+	ch := make(chan Report)
+	go l.run(ch)
+	return ch
+}
+
+type sample struct {
+	at      time.Time
+	percent float32
+}
+
+func (l *logSinker) run(ch <-chan Report) {
+	var (
+		window []sample
+		last   time.Time
+		final  Report
+	)
+
+	for report := range ch {
+		final = report
+		now := time.Now()
+		window = appendSample(window, sample{now, report.Percentage()})
+
+		if !last.IsZero() && now.Sub(last) < logInterval {
+			continue
+		}
+		last = now
+
+		l.log("%s: %s", l.prefix, formatProgress(report, window))
+	}
+
+	if final == nil {
+		return
+	}
+
+	if err := final.Error(); err != nil {
+		l.log("%s: error: %s", l.prefix, err)
+		return
+	}
+
+	l.log("%s: 100%% done", l.prefix)
+}
+
+func appendSample(window []sample, s sample) []sample {
+	window = append(window, s)
+	if len(window) > sampleWindow {
+		window = window[len(window)-sampleWindow:]
+	}
+	return window
+}
+
+func formatProgress(report Report, window []sample) string {
+	pct := report.Percentage()
+
+	rate, ok := byteRate(report, window)
+	eta, etaOK := estimateETA(pct, window)
+
+	switch {
+	case ok && etaOK:
+		return fmt.Sprintf("%.0f%% (%s/s, ETA %s)", pct, formatBytes(rate), eta)
+	case etaOK:
+		return fmt.Sprintf("%.0f%% (ETA %s)", pct, eta)
+	default:
+		return fmt.Sprintf("%.0f%%", pct)
+	}
+}
+
+// byteRate estimates bytes/sec from the percentage delta across window, when
+// report carries a known total size.
+func byteRate(report Report, window []sample) (float64, bool) {
+	s, ok := report.(sizer)
+	if !ok || len(window) < 2 {
+		return 0, false
+	}
+
+	total := float64(s.Size())
+	if total <= 0 {
+		return 0, false
+	}
+
+	first, last := window[0], window[len(window)-1]
+	elapsed := last.at.Sub(first.at).Seconds()
+	if elapsed <= 0 {
+		return 0, false
+	}
+
+	deltaPct := float64(last.percent - first.percent)
+	return (deltaPct / 100) * total / elapsed, true
+}
+
+// estimateETA extrapolates the time remaining to 100% from the percentage
+// delta observed across window.
+func estimateETA(pct float32, window []sample) (time.Duration, bool) {
+	if len(window) < 2 || pct <= 0 || pct >= 100 {
+		return 0, false
+	}
+
+	first, last := window[0], window[len(window)-1]
+	elapsed := last.at.Sub(first.at).Seconds()
+	deltaPct := float64(last.percent - first.percent)
+	if elapsed <= 0 || deltaPct <= 0 {
+		return 0, false
+	}
+
+	remaining := float64(100 - pct)
+	secs := remaining / (deltaPct / elapsed)
+
+	return time.Duration(secs * float64(time.Second)).Round(time.Second), true
+}
+
+func formatBytes(n float64) string {
+	const unit = 1024.0
+	units := []string{"B", "KiB", "MiB", "GiB", "TiB"}
+
+	i := 0
+	for n >= unit && i < len(units)-1 {
+		n /= unit
+		i++
+	}
+
+	return fmt.Sprintf("%.1f %s", n, units[i])
+}
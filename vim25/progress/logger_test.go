@@ -0,0 +1,101 @@
+/*
+Copyright (c) 2024-2024 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package progress
+
+import (
+	"testing"
+	"time"
+)
+
+type fakeReport struct {
+	percent float32
+	detail  string
+	err     error
+	size    int64
+}
+
+func (r fakeReport) Percentage() float32 { return r.percent }
+func (r fakeReport) Detail() string      { return r.detail }
+func (r fakeReport) Error() error        { return r.err }
+func (r fakeReport) Size() int64         { return r.size }
+
+func TestFormatBytes(t *testing.T) {
+	tests := []struct {
+		in   float64
+		want string
+	}{
+		{0, "0.0 B"},
+		{512, "512.0 B"},
+		{1024, "1.0 KiB"},
+		{1.5 * 1024 * 1024, "1.5 MiB"},
+		{1024 * 1024 * 1024, "1.0 GiB"},
+	}
+
+	for _, tt := range tests {
+		if got := formatBytes(tt.in); got != tt.want {
+			t.Errorf("formatBytes(%v) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestEstimateETA(t *testing.T) {
+	now := time.Now()
+
+	window := []sample{
+		{now, 0},
+		{now.Add(10 * time.Second), 50},
+	}
+
+	eta, ok := estimateETA(50, window)
+	if !ok {
+		t.Fatal("expected an ETA estimate")
+	}
+	if eta != 10*time.Second {
+		t.Errorf("eta = %s, want 10s", eta)
+	}
+
+	if _, ok := estimateETA(100, window); ok {
+		t.Error("expected no ETA at 100%")
+	}
+
+	if _, ok := estimateETA(50, window[:1]); ok {
+		t.Error("expected no ETA with a single sample")
+	}
+}
+
+func TestByteRate(t *testing.T) {
+	now := time.Now()
+
+	window := []sample{
+		{now, 0},
+		{now.Add(10 * time.Second), 50},
+	}
+
+	report := fakeReport{percent: 50, size: 1000}
+
+	rate, ok := byteRate(report, window)
+	if !ok {
+		t.Fatal("expected a byte rate estimate")
+	}
+	if rate != 50 {
+		t.Errorf("rate = %v, want 50 bytes/s", rate)
+	}
+
+	if _, ok := byteRate(fakeReport{percent: 50}, window); ok {
+		t.Error("expected no rate without a known size")
+	}
+}
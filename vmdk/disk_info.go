@@ -0,0 +1,147 @@
+/*
+Copyright (c) 2024-2024 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package vmdk provides helpers for inspecting virtual disks attached to a
+// VM, such as resolving the datastore path(s) of a disk's snapshot chain
+// and its thin-provisioning/CBT state. It exists so that tools like
+// stembuild or packer can make a single call instead of hand-rolling
+// property collection plus VirtualDiskManager lookups.
+package vmdk
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+// DiskInfo is the result of resolving a VM's disk UUID to its full backing
+// chain, as reported by VirtualDiskManager.QueryVirtualDiskInfoEx.
+type DiskInfo struct {
+	// Key is the VirtualDisk device key on the VM the UUID was resolved from.
+	Key int32
+	// UUID is the virtual disk UUID, as reported by the guest or the API.
+	UUID string
+
+	CapacityInBytes       int64
+	Filename              string
+	UniqueSize            int64
+	ThinProvisioned       bool
+	ChangeTrackingEnabled bool
+
+	// Parents lists the descriptors of the chain above Filename, ordered
+	// from the immediate parent to the base disk.
+	Parents []ParentDiskInfo
+}
+
+// ParentDiskInfo describes a single link in a disk's snapshot chain.
+type ParentDiskInfo struct {
+	Filename        string
+	CapacityInBytes int64
+	UniqueSize      int64
+	ThinProvisioned bool
+}
+
+// GetDiskInfoByUUID finds the VirtualDisk device attached to vm whose
+// backing UUID matches uuid, resolves its datastore path(s) and returns a
+// DiskInfo describing the full parent chain.
+func GetDiskInfoByUUID(ctx context.Context, vm *object.VirtualMachine, uuid string) (*DiskInfo, error) {
+	devices, err := vm.Device(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	disk, backing, err := findDiskByUUID(devices, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	dc, err := vm.Datacenter(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	m := object.NewVirtualDiskManager(vm.Client())
+
+	chain, err := m.QueryVirtualDiskInfoEx(ctx, backing.GetVirtualDeviceFileBackingInfo().FileName, dc, true)
+	if err != nil {
+		return nil, fmt.Errorf("vmdk: querying disk info for %q: %w", backing.GetVirtualDeviceFileBackingInfo().FileName, err)
+	}
+	if len(chain) == 0 {
+		return nil, fmt.Errorf("vmdk: no disk info returned for uuid %q", uuid)
+	}
+
+	info := &DiskInfo{
+		Key:  disk.Key,
+		UUID: uuid,
+	}
+
+	for i, link := range chain {
+		if i == 0 {
+			info.CapacityInBytes = link.CapacityInBytes
+			info.Filename = link.Name
+			info.UniqueSize = link.UniqueSize
+			info.ThinProvisioned = link.ThinProvisioned != nil && *link.ThinProvisioned
+			info.ChangeTrackingEnabled = link.ChangeTrackingEnabled != nil && *link.ChangeTrackingEnabled
+			continue
+		}
+
+		info.Parents = append(info.Parents, ParentDiskInfo{
+			Filename:        link.Name,
+			CapacityInBytes: link.CapacityInBytes,
+			UniqueSize:      link.UniqueSize,
+			ThinProvisioned: link.ThinProvisioned != nil && *link.ThinProvisioned,
+		})
+	}
+
+	return info, nil
+}
+
+func findDiskByUUID(devices object.VirtualDeviceList, uuid string) (*types.VirtualDisk, types.BaseVirtualDeviceFileBackingInfo, error) {
+	for _, d := range devices.SelectByType((*types.VirtualDisk)(nil)) {
+		disk, ok := d.(*types.VirtualDisk)
+		if !ok {
+			continue
+		}
+
+		backing, ok := disk.Backing.(types.BaseVirtualDeviceFileBackingInfo)
+		if !ok {
+			continue
+		}
+
+		if diskBackingUUID(disk.Backing) == uuid {
+			return disk, backing, nil
+		}
+	}
+
+	return nil, nil, fmt.Errorf("vmdk: no disk with uuid %q attached", uuid)
+}
+
+func diskBackingUUID(backing types.BaseVirtualDeviceBackingInfo) string {
+	switch b := backing.(type) {
+	case *types.VirtualDiskFlatVer2BackingInfo:
+		return b.Uuid
+	case *types.VirtualDiskSparseVer2BackingInfo:
+		return b.Uuid
+	case *types.VirtualDiskSeSparseBackingInfo:
+		return b.Uuid
+	case *types.VirtualDiskRawDiskMappingVer1BackingInfo:
+		return b.Uuid
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,66 @@
+/*
+Copyright (c) 2024-2024 VMware, Inc. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vmdk
+
+import (
+	"testing"
+
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+func TestDiskBackingUUID(t *testing.T) {
+	tests := []struct {
+		name    string
+		backing types.BaseVirtualDeviceBackingInfo
+		want    string
+	}{
+		{
+			name:    "flat",
+			backing: &types.VirtualDiskFlatVer2BackingInfo{Uuid: "uuid-flat"},
+			want:    "uuid-flat",
+		},
+		{
+			name:    "sparse",
+			backing: &types.VirtualDiskSparseVer2BackingInfo{Uuid: "uuid-sparse"},
+			want:    "uuid-sparse",
+		},
+		{
+			name:    "seSparse",
+			backing: &types.VirtualDiskSeSparseBackingInfo{Uuid: "uuid-sesparse"},
+			want:    "uuid-sesparse",
+		},
+		{
+			name:    "rdm",
+			backing: &types.VirtualDiskRawDiskMappingVer1BackingInfo{Uuid: "uuid-rdm"},
+			want:    "uuid-rdm",
+		},
+		{
+			name:    "default",
+			backing: &types.VirtualDiskFlatVer1BackingInfo{},
+			want:    "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := diskBackingUUID(tt.backing)
+			if got != tt.want {
+				t.Errorf("diskBackingUUID(%T) = %q, want %q", tt.backing, got, tt.want)
+			}
+		})
+	}
+}